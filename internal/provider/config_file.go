@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+// defaultConfigFile is used when neither the provider block nor
+// AZUREIPAM_CONFIG_FILE specify a path.
+const defaultConfigFile = "~/.azureipam/config.json"
+
+// configFile is the shape of the on-disk profile file, e.g.:
+//
+//	{"configs":[{"alias":"prod","description":"...","endpoint":"https://...","token":"...","tenant_id":"..."}]}
+type configFile struct {
+	Configs []configFileProfile `json:"configs"`
+}
+
+// configFileProfile is a single named profile within a configFile.
+type configFileProfile struct {
+	Alias       string `json:"alias"`
+	Description string `json:"description"`
+	Endpoint    string `json:"endpoint"`
+	Token       string `json:"token"`
+	TenantID    string `json:"tenant_id"`
+}
+
+// loadConfigProfile reads path (expanding a leading ~) and returns the profile
+// whose alias matches profileName.
+func loadConfigProfile(path, profileName string) (*configFileProfile, error) {
+	expanded, err := homedir.Expand(path)
+	if err != nil {
+		return nil, fmt.Errorf("expand config_file path %q: %w", path, err)
+	}
+
+	data, err := os.ReadFile(expanded)
+	if err != nil {
+		return nil, fmt.Errorf("read config_file %q: %w", expanded, err)
+	}
+
+	var cfg configFile
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse config_file %q: %w", expanded, err)
+	}
+
+	for _, profile := range cfg.Configs {
+		if profile.Alias == profileName {
+			return &profile, nil
+		}
+	}
+	return nil, fmt.Errorf("profile %q not found in config_file %q", profileName, expanded)
+}
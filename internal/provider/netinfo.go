@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"fmt"
+	"math/big"
+	"net/netip"
+)
+
+// networkAttrs holds the attributes derived from a reservation's cidr.
+type networkAttrs struct {
+	Network         string
+	Netmask         string
+	PrefixLength    int64
+	Gateway         string
+	Broadcast       string
+	Family          int64
+	UsableHostCount string
+}
+
+// computeNetworkAttrs derives network, netmask, gateway, broadcast, family
+// and usable host count from a cidr using net/netip so that both IPv4 and
+// IPv6 reservations are handled correctly. gatewayPosition is "first_host"
+// (default, used for anything other than "last_host") or "last_host" and
+// selects which usable host is reported as the gateway.
+func computeNetworkAttrs(cidr string, gatewayPosition string) (networkAttrs, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return networkAttrs{}, fmt.Errorf("parse cidr %q: %w", cidr, err)
+	}
+	prefix = prefix.Masked()
+
+	network := prefix.Addr()
+	family := int64(6)
+	bits := 128
+	if network.Is4() {
+		family = 4
+		bits = 32
+	}
+	hostBits := bits - prefix.Bits()
+
+	attrs := networkAttrs{
+		Network:      network.String(),
+		PrefixLength: int64(prefix.Bits()),
+		Family:       family,
+	}
+
+	if family == 4 {
+		mask := ^uint32(0) << uint(hostBits)
+		if hostBits >= 32 {
+			mask = 0
+		}
+		netBytes := network.As4()
+		netInt := uint32(netBytes[0])<<24 | uint32(netBytes[1])<<16 | uint32(netBytes[2])<<8 | uint32(netBytes[3])
+		maskBytes := [4]byte{byte(mask >> 24), byte(mask >> 16), byte(mask >> 8), byte(mask)}
+		attrs.Netmask = netip.AddrFrom4(maskBytes).String()
+
+		broadcastInt := netInt | ^mask
+		attrs.Broadcast = netip.AddrFrom4([4]byte{byte(broadcastInt >> 24), byte(broadcastInt >> 16), byte(broadcastInt >> 8), byte(broadcastInt)}).String()
+
+		first := network.Next()
+		lastHost, err := hostAtOffset(network, hostBits, -2)
+		if err != nil {
+			return networkAttrs{}, err
+		}
+
+		switch {
+		case prefix.Bits() == 32:
+			attrs.Gateway = network.String()
+			attrs.UsableHostCount = "1"
+		case prefix.Bits() == 31:
+			attrs.Gateway = network.String()
+			if gatewayPosition == "last_host" {
+				attrs.Gateway = first.String()
+			}
+			attrs.UsableHostCount = "2"
+		default:
+			if gatewayPosition == "last_host" {
+				attrs.Gateway = lastHost.String()
+			} else {
+				attrs.Gateway = first.String()
+			}
+			attrs.UsableHostCount = new(big.Int).Sub(big.NewInt(1<<uint(hostBits)), big.NewInt(2)).String()
+		}
+	} else {
+		attrs.Netmask = fmt.Sprintf("%d", prefix.Bits())
+
+		total := new(big.Int).Lsh(big.NewInt(1), uint(hostBits))
+		attrs.UsableHostCount = total.String()
+
+		switch {
+		case prefix.Bits() == 128, prefix.Bits() == 127:
+			attrs.Gateway = network.String()
+			if prefix.Bits() == 127 && gatewayPosition == "last_host" {
+				attrs.Gateway = network.Next().String()
+			}
+		default:
+			first := network.Next()
+			lastHost, err := hostAtOffset(network, hostBits, -1)
+			if err != nil {
+				return networkAttrs{}, err
+			}
+			if gatewayPosition == "last_host" {
+				attrs.Gateway = lastHost.String()
+			} else {
+				attrs.Gateway = first.String()
+			}
+		}
+	}
+
+	return attrs, nil
+}
+
+// cidrPrefixLength returns the network address and prefix length of a cidr.
+func cidrPrefixLength(cidr string) (string, int, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return "", 0, fmt.Errorf("parse cidr %q: %w", cidr, err)
+	}
+	prefix = prefix.Masked()
+	return prefix.Addr().String(), prefix.Bits(), nil
+}
+
+// hostAtOffset returns network + 2^hostBits + offset, i.e. it counts back from
+// the end of the `hostBits`-wide network: offset -1 is the network's last
+// (broadcast) address and -2 the address just before it.
+func hostAtOffset(network netip.Addr, hostBits int, offset int) (netip.Addr, error) {
+	last := network
+	total := new(big.Int).Lsh(big.NewInt(1), uint(hostBits))
+	target := new(big.Int).Add(total, big.NewInt(int64(offset)))
+
+	slice := last.AsSlice()
+	base := new(big.Int).SetBytes(slice)
+	base.Add(base, target)
+	result := base.Bytes()
+
+	out := make([]byte, len(slice))
+	copy(out[len(out)-len(result):], result)
+
+	addr, ok := netip.AddrFromSlice(out)
+	if !ok {
+		return netip.Addr{}, fmt.Errorf("compute host address: invalid result for network %s", network)
+	}
+	return addr, nil
+}
@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"os"
+
+	ipamclient "terraform-provider-azureipam/ipamclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ provider.Provider = &azureipamProvider{}
+
+// New is a helper function to simplify provider server and testing implementation.
+func New(version string) func() provider.Provider {
+	return func() provider.Provider {
+		return &azureipamProvider{
+			version: version,
+		}
+	}
+}
+
+// azureipamProvider is the provider implementation.
+type azureipamProvider struct {
+	// version is set to the provider version on release, "dev" when the
+	// provider is built and run locally, and "test" when running acceptance
+	// testing.
+	version string
+}
+
+// azureipamProviderModel maps the provider schema data.
+type azureipamProviderModel struct {
+	Endpoint        types.String `tfsdk:"endpoint"`
+	Token           types.String `tfsdk:"token"`
+	ConfigFile      types.String `tfsdk:"config_file"`
+	Profile         types.String `tfsdk:"profile"`
+	GatewayPosition types.String `tfsdk:"gateway_position"`
+}
+
+// Metadata returns the provider type name.
+func (p *azureipamProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "azureipam"
+	resp.Version = p.version
+}
+
+// Schema defines the provider-level schema for configuration data.
+func (p *azureipamProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Interact with an Azure IPAM instance.",
+		Attributes: map[string]schema.Attribute{
+			"endpoint": schema.StringAttribute{
+				Description: "The base URL of the Azure IPAM instance. Takes precedence over the selected config file profile and the AZUREIPAM_ENDPOINT environment variable.",
+				Optional:    true,
+			},
+			"token": schema.StringAttribute{
+				Description: "The bearer token used to authenticate against the Azure IPAM instance. Takes precedence over the selected config file profile and the AZUREIPAM_TOKEN environment variable.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"config_file": schema.StringAttribute{
+				Description: "Path to a ztctl-style config file with named profiles, e.g. `{\"configs\":[{\"alias\":\"prod\",\"endpoint\":\"...\",\"token\":\"...\"}]}`. Defaults to `~/.azureipam/config.json`, overridable with AZUREIPAM_CONFIG_FILE.",
+				Optional:    true,
+			},
+			"profile": schema.StringAttribute{
+				Description: "Alias of the profile to load from config_file. Overridable with AZUREIPAM_PROFILE.",
+				Optional:    true,
+			},
+			"gateway_position": schema.StringAttribute{
+				Description: "Which usable host in a reservation's cidr is reported as its `gateway` attribute: `first_host` (default) or `last_host`.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// Configure resolves connection settings (explicit attributes > config file
+// profile > environment variables) and prepares an ipamclient.Client for data
+// sources and resources to use.
+func (p *azureipamProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var config azureipamProviderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cfg := ipamclient.Config{
+		Endpoint: config.Endpoint.ValueString(),
+		Token:    config.Token.ValueString(),
+	}
+
+	configFilePath := config.ConfigFile.ValueString()
+	if configFilePath == "" {
+		configFilePath = os.Getenv("AZUREIPAM_CONFIG_FILE")
+	}
+	if configFilePath == "" {
+		configFilePath = defaultConfigFile
+	}
+
+	profileName := config.Profile.ValueString()
+	if profileName == "" {
+		profileName = os.Getenv("AZUREIPAM_PROFILE")
+	}
+
+	if profileName != "" {
+		profile, err := loadConfigProfile(configFilePath, profileName)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Load AzureIpam Config Profile", err.Error())
+			return
+		}
+		if cfg.Endpoint == "" {
+			cfg.Endpoint = profile.Endpoint
+		}
+		if cfg.Token == "" {
+			cfg.Token = profile.Token
+		}
+		if cfg.TenantID == "" {
+			cfg.TenantID = profile.TenantID
+		}
+	}
+
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = os.Getenv("AZUREIPAM_ENDPOINT")
+	}
+	if cfg.Token == "" {
+		cfg.Token = os.Getenv("AZUREIPAM_TOKEN")
+	}
+
+	if cfg.Endpoint == "" {
+		resp.Diagnostics.AddError(
+			"Missing AzureIpam Endpoint Configuration",
+			"The provider cannot create the AzureIpam API client as there is no known value for the endpoint. "+
+				"Set the endpoint value in the provider configuration, in the selected config_file profile, or via the AZUREIPAM_ENDPOINT environment variable.",
+		)
+	}
+	if cfg.Token == "" {
+		resp.Diagnostics.AddError(
+			"Missing AzureIpam Token Configuration",
+			"The provider cannot create the AzureIpam API client as there is no known value for the token. "+
+				"Set the token value in the provider configuration, in the selected config_file profile, or via the AZUREIPAM_TOKEN environment variable.",
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch config.GatewayPosition.ValueString() {
+	case "last_host":
+		cfg.GatewayPosition = "last_host"
+	case "", "first_host":
+		cfg.GatewayPosition = "first_host"
+	default:
+		resp.Diagnostics.AddError(
+			"Invalid Configuration",
+			"gateway_position must be either \"first_host\" or \"last_host\".",
+		)
+		return
+	}
+
+	client := ipamclient.NewClientFromConfig(cfg)
+	resp.DataSourceData = client
+	resp.ResourceData = client
+}
+
+// DataSources defines the data sources implemented in the provider.
+func (p *azureipamProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{
+		NewReservationDataSource,
+		NewReservationsDataSource,
+	}
+}
+
+// Resources defines the resources implemented in the provider.
+func (p *azureipamProvider) Resources(_ context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		NewReservationResource,
+		NewReservationGroupResource,
+	}
+}
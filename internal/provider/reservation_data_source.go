@@ -31,17 +31,24 @@ type reservationDataSource struct {
 
 // reservationDataSourceModel maps the data source schema data.
 type reservationDataSourceModel struct {
-	Space       types.String      `tfsdk:"space"`
-	Blocks      types.List        `tfsdk:"blocks"`
-	Id          types.String      `tfsdk:"id"`
-	Cidr        types.String      `tfsdk:"cidr"`
-	Description types.String      `tfsdk:"description"`
-	CreatedOn   timetypes.RFC3339 `tfsdk:"created_on"`
-	CreatedBy   types.String      `tfsdk:"created_by"`
-	SettledOn   timetypes.RFC3339 `tfsdk:"settled_on"`
-	SettledBy   types.String      `tfsdk:"settled_by"`
-	Status      types.String      `tfsdk:"status"`
-	Tags        types.Map         `tfsdk:"tags"`
+	Space           types.String      `tfsdk:"space"`
+	Blocks          types.List        `tfsdk:"blocks"`
+	Id              types.String      `tfsdk:"id"`
+	Cidr            types.String      `tfsdk:"cidr"`
+	Description     types.String      `tfsdk:"description"`
+	CreatedOn       timetypes.RFC3339 `tfsdk:"created_on"`
+	CreatedBy       types.String      `tfsdk:"created_by"`
+	SettledOn       timetypes.RFC3339 `tfsdk:"settled_on"`
+	SettledBy       types.String      `tfsdk:"settled_by"`
+	Status          types.String      `tfsdk:"status"`
+	Tags            types.Map         `tfsdk:"tags"`
+	Network         types.String      `tfsdk:"network"`
+	Netmask         types.String      `tfsdk:"netmask"`
+	PrefixLength    types.Int64       `tfsdk:"prefix_length"`
+	Gateway         types.String      `tfsdk:"gateway"`
+	Broadcast       types.String      `tfsdk:"broadcast"`
+	Family          types.Int64       `tfsdk:"family"`
+	UsableHostCount types.String      `tfsdk:"usable_host_count"`
 }
 
 // Metadata returns the data source type name.
@@ -102,6 +109,34 @@ func (d *reservationDataSource) Schema(_ context.Context, _ datasource.SchemaReq
 				Computed:    true,
 				ElementType: types.StringType,
 			},
+			"network": schema.StringAttribute{
+				Description: "The network address of the reserved cidr.",
+				Computed:    true,
+			},
+			"netmask": schema.StringAttribute{
+				Description: "The netmask of the reserved cidr, in dotted-quad notation for IPv4 or as a prefix length for IPv6.",
+				Computed:    true,
+			},
+			"prefix_length": schema.Int64Attribute{
+				Description: "The prefix length of the reserved cidr.",
+				Computed:    true,
+			},
+			"gateway": schema.StringAttribute{
+				Description: "The first (or last, depending on the provider's gateway_position setting) usable host address in the reserved cidr.",
+				Computed:    true,
+			},
+			"broadcast": schema.StringAttribute{
+				Description: "The broadcast address of the reserved cidr. Only set for IPv4 reservations.",
+				Computed:    true,
+			},
+			"family": schema.Int64Attribute{
+				Description: "The IP address family of the reserved cidr, 4 or 6.",
+				Computed:    true,
+			},
+			"usable_host_count": schema.StringAttribute{
+				Description: "The number of usable host addresses in the reserved cidr, as a string to accommodate large IPv6 ranges.",
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -162,7 +197,10 @@ func (d *reservationDataSource) Read(ctx context.Context, req datasource.ReadReq
 
 	//  Map response body to state model
 	var model reservationResourceModel //to reuse existing flatten method
-	flattenReservation(reservation, &model)
+	if err := flattenReservation(reservation, &model, d.client.GatewayPosition); err != nil {
+		resp.Diagnostics.AddError("Unable to Derive Network Attributes", err.Error())
+		return
+	}
 	state.Id = model.Id
 	state.Cidr = model.Cidr
 	state.Description = model.Description
@@ -172,6 +210,13 @@ func (d *reservationDataSource) Read(ctx context.Context, req datasource.ReadReq
 	state.SettledBy = model.SettledBy
 	state.Status = model.Status
 	state.Tags, _ = types.MapValueFrom(ctx, types.StringType, reservation.Tags)
+	state.Network = model.Network
+	state.Netmask = model.Netmask
+	state.PrefixLength = model.PrefixLength
+	state.Gateway = model.Gateway
+	state.Broadcast = model.Broadcast
+	state.Family = model.Family
+	state.UsableHostCount = model.UsableHostCount
 
 	// Set state
 	diags := resp.State.Set(ctx, &state)
@@ -0,0 +1,523 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	ipamclient "terraform-provider-azureipam/ipamclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &reservationGroupResource{}
+	_ resource.ResourceWithConfigure = &reservationGroupResource{}
+)
+
+// NewReservationGroupResource is a helper function to simplify the provider implementation.
+func NewReservationGroupResource() resource.Resource {
+	return &reservationGroupResource{}
+}
+
+// reservationGroupResource is the resource implementation.
+type reservationGroupResource struct {
+	client *ipamclient.Client
+}
+
+// reservationGroupRequestModel describes a single CIDR to allocate as part of the group.
+type reservationGroupRequestModel struct {
+	Size        types.Int64  `tfsdk:"size"`
+	Description types.String `tfsdk:"description"`
+	Tags        types.Map    `tfsdk:"tags"`
+}
+
+// reservationGroupChildModel is a single allocated reservation within the group.
+type reservationGroupChildModel struct {
+	Id   types.String `tfsdk:"id"`
+	Cidr types.String `tfsdk:"cidr"`
+	Tags types.Map    `tfsdk:"tags"`
+}
+
+// reservationGroupResourceModel maps the resource schema data.
+type reservationGroupResourceModel struct {
+	Id           types.String                   `tfsdk:"id"`
+	Space        types.String                   `tfsdk:"space"`
+	Blocks       types.List                     `tfsdk:"blocks"`
+	Requests     []reservationGroupRequestModel `tfsdk:"requests"`
+	Reservations []reservationGroupChildModel   `tfsdk:"reservations"`
+}
+
+// Metadata returns the resource type name.
+func (r *reservationGroupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_reservation_group"
+}
+
+// Schema defines the schema for the resource.
+func (r *reservationGroupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The reservation group resource atomically reserves a set of related CIDRs (e.g. a hub and its spokes) as a single Terraform-managed unit.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier of the reservation group (the id of its first child reservation).",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"space": schema.StringAttribute{
+				Description: "Name of the space where the reservations are allocated.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"blocks": schema.ListAttribute{
+				Description: "Ordered list of block names to try for each request. The first block with enough free space for a given request wins.",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+			"requests": schema.ListNestedAttribute{
+				Description: "Ordered list of CIDRs to reserve. Each entry becomes one child reservation, indexable as `reservations[n]`.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"size": schema.Int64Attribute{
+							Description: "Prefix length of the CIDR to reserve, e.g. 24 for a /24. Changing this forces replacement of this request's child reservation.",
+							Required:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "Description text that describe the reservation.",
+							Required:    true,
+						},
+						"tags": schema.MapAttribute{
+							Description: "Tags to associate with the reservation.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+			"reservations": schema.ListNestedAttribute{
+				Description: "The child reservations allocated for each entry in `requests`, in the same order.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The unique identifier of the child reservation.",
+							Computed:    true,
+						},
+						"cidr": schema.StringAttribute{
+							Description: "The assigned and reserved range, in cidr notation.",
+							Computed:    true,
+						},
+						"tags": schema.MapAttribute{
+							Description: "Tags associated with the child reservation.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Create atomically reserves every entry in requests, rolling back on any failure.
+func (r *reservationGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan reservationGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var blocks []string
+	resp.Diagnostics.Append(plan.Blocks.ElementsAs(ctx, &blocks, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(blocks) == 0 {
+		resp.Diagnostics.AddError(
+			"Invalid Configuration",
+			"At least one block must be specified in 'blocks'.",
+		)
+		return
+	}
+	if len(plan.Requests) == 0 {
+		resp.Diagnostics.AddError(
+			"Invalid Configuration",
+			"At least one request must be specified in 'requests'.",
+		)
+		return
+	}
+
+	var children []childReservation
+	for i, request := range plan.Requests {
+		var tags map[string]string
+		if !request.Tags.IsNull() {
+			resp.Diagnostics.Append(request.Tags.ElementsAs(ctx, &tags, false)...)
+			if resp.Diagnostics.HasError() {
+				r.rollbackAndWarn(&resp.Diagnostics, plan.Space.ValueString(), children)
+				return
+			}
+		}
+
+		reservation, block, err := r.createInFirstAvailableBlock(plan.Space.ValueString(), blocks, ipamclient.CreateReservationRequest{
+			Size:        int(request.Size.ValueInt64()),
+			Description: request.Description.ValueString(),
+			Tags:        tags,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Create AzureIpam Reservation Group",
+				fmt.Sprintf("request[%d] space=%s error=%s; rolling back %d previously created reservation(s)", i, plan.Space.ValueString(), err.Error(), len(children)),
+			)
+			r.rollbackAndWarn(&resp.Diagnostics, plan.Space.ValueString(), children)
+			return
+		}
+		children = append(children, childReservation{reservation: reservation, block: block})
+	}
+
+	if err := setReservationGroupChildren(ctx, &plan, children); err != nil {
+		resp.Diagnostics.AddError("Unable to Map Reservation Group Children", err.Error())
+		r.rollbackAndWarn(&resp.Diagnostics, plan.Space.ValueString(), children)
+		return
+	}
+	if len(children) > 0 {
+		plan.Id = types.StringValue(children[0].reservation.Id)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data for every child reservation.
+func (r *reservationGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state reservationGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var blocks []string
+	resp.Diagnostics.Append(state.Blocks.ElementsAs(ctx, &blocks, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var children []childReservation
+	for i, existing := range state.Reservations {
+		reservation, block, err := r.findInBlocks(state.Space.ValueString(), blocks, existing.Id.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read AzureIpam Reservation Group",
+				fmt.Sprintf("reservations[%d] id=%s error=%s", i, existing.Id.ValueString(), err.Error()),
+			)
+			return
+		}
+		children = append(children, childReservation{reservation: reservation, block: block})
+	}
+
+	if err := setReservationGroupChildren(ctx, &state, children); err != nil {
+		resp.Diagnostics.AddError("Unable to Map Reservation Group Children", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update applies description/tag changes to existing children and replaces any child
+// whose request size changed or whose request was added/removed.
+func (r *reservationGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan reservationGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	var state reservationGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var blocks []string
+	resp.Diagnostics.Append(plan.Blocks.ElementsAs(ctx, &blocks, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var children []childReservation
+	for i, request := range plan.Requests {
+		var tags map[string]string
+		if !request.Tags.IsNull() {
+			resp.Diagnostics.Append(request.Tags.ElementsAs(ctx, &tags, false)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		if i >= len(state.Reservations) {
+			// a new request was appended: create its child reservation.
+			reservation, block, err := r.createInFirstAvailableBlock(plan.Space.ValueString(), blocks, ipamclient.CreateReservationRequest{
+				Size:        int(request.Size.ValueInt64()),
+				Description: request.Description.ValueString(),
+				Tags:        tags,
+			})
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to Create AzureIpam Reservation Group Child", fmt.Sprintf("request[%d] error=%s", i, err.Error()))
+				r.persistPartialUpdate(ctx, resp, &plan, state.Id, children, nil)
+				return
+			}
+			resp.Diagnostics.AddWarning("Reservation Group Child Created", fmt.Sprintf("requests[%d] is new and was allocated as %s", i, reservation.Cidr))
+			children = append(children, childReservation{reservation: reservation, block: block})
+			continue
+		}
+
+		existing := state.Reservations[i]
+		current, block, err := r.findInBlocks(state.Space.ValueString(), blocks, existing.Id.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Read AzureIpam Reservation Group Child", fmt.Sprintf("requests[%d] id=%s error=%s", i, existing.Id.ValueString(), err.Error()))
+			r.persistPartialUpdate(ctx, resp, &plan, state.Id, children, state.Reservations[i+1:])
+			return
+		}
+
+		_, currentPrefixLen, err := cidrPrefixLength(current.Cidr)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Parse Existing Reservation Cidr", err.Error())
+			r.persistPartialUpdate(ctx, resp, &plan, state.Id, children, state.Reservations[i+1:])
+			return
+		}
+
+		if int64(currentPrefixLen) != request.Size.ValueInt64() {
+			resp.Diagnostics.AddWarning(
+				"Reservation Group Child Replaced",
+				fmt.Sprintf("requests[%d] size changed from /%d to /%d; settling %s and allocating a replacement", i, currentPrefixLen, request.Size.ValueInt64(), current.Id),
+			)
+			if current.Status != "settled" {
+				if err := r.client.SettleReservation(plan.Space.ValueString(), block, current.Id); err != nil {
+					resp.Diagnostics.AddError("Unable to Settle Replaced Reservation", fmt.Sprintf("requests[%d] id=%s error=%s", i, current.Id, err.Error()))
+					r.persistPartialUpdate(ctx, resp, &plan, state.Id, children, state.Reservations[i+1:])
+					return
+				}
+			}
+			reservation, newBlock, err := r.createInFirstAvailableBlock(plan.Space.ValueString(), blocks, ipamclient.CreateReservationRequest{
+				Size:        int(request.Size.ValueInt64()),
+				Description: request.Description.ValueString(),
+				Tags:        tags,
+			})
+			if err != nil {
+				// current.Id was already settled above: this child is now
+				// unallocated. Only the children built so far, plus any
+				// requests[i+1:] children untouched this call, can be persisted.
+				resp.Diagnostics.AddError("Unable to Create Replacement Reservation", fmt.Sprintf("requests[%d] error=%s", i, err.Error()))
+				r.persistPartialUpdate(ctx, resp, &plan, state.Id, children, state.Reservations[i+1:])
+				return
+			}
+			children = append(children, childReservation{reservation: reservation, block: newBlock})
+			continue
+		}
+
+		updated, err := r.client.UpdateReservation(plan.Space.ValueString(), block, current.Id, ipamclient.UpdateReservationRequest{
+			Description: request.Description.ValueString(),
+			Tags:        tags,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Update AzureIpam Reservation Group Child", fmt.Sprintf("requests[%d] id=%s error=%s", i, current.Id, err.Error()))
+			r.persistPartialUpdate(ctx, resp, &plan, state.Id, children, state.Reservations[i+1:])
+			return
+		}
+		children = append(children, childReservation{reservation: updated, block: block})
+	}
+
+	// any state reservations beyond the new request count are no longer wanted.
+	// reservations that fail to settle are kept in children (rather than
+	// dropped) so that a future apply retries settling them instead of
+	// silently losing track of them.
+	for i := len(plan.Requests); i < len(state.Reservations); i++ {
+		removed := state.Reservations[i]
+		current, block, err := r.findInBlocks(state.Space.ValueString(), blocks, removed.Id.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddWarning(
+				"Unable to Locate Removed Reservation Group Child",
+				fmt.Sprintf("reservations[%d] id=%s error=%s; it will be dropped from state but may still exist in IPAM", i, removed.Id.ValueString(), err.Error()),
+			)
+			continue
+		}
+		if current.Status == "settled" {
+			continue
+		}
+		if err := r.client.SettleReservation(state.Space.ValueString(), block, removed.Id.ValueString()); err != nil {
+			resp.Diagnostics.AddWarning(
+				"Unable to Settle Removed Reservation Group Child",
+				fmt.Sprintf("reservations[%d] id=%s error=%s; it will remain in state and should be retried on the next apply", i, removed.Id.ValueString(), err.Error()),
+			)
+			children = append(children, childReservation{reservation: current, block: block})
+		}
+	}
+
+	if err := setReservationGroupChildren(ctx, &plan, children); err != nil {
+		resp.Diagnostics.AddError("Unable to Map Reservation Group Children", err.Error())
+		return
+	}
+	if len(children) > 0 {
+		plan.Id = types.StringValue(children[0].reservation.Id)
+	} else {
+		plan.Id = state.Id
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete settles every child reservation, tolerating children that are already settled.
+func (r *reservationGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state reservationGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var blocks []string
+	resp.Diagnostics.Append(state.Blocks.ElementsAs(ctx, &blocks, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, child := range state.Reservations {
+		current, block, err := r.findInBlocks(state.Space.ValueString(), blocks, child.Id.ValueString())
+		if err != nil {
+			// already gone; nothing left to settle.
+			continue
+		}
+		if current.Status == "settled" {
+			// already settled; re-submitting would be a redundant settle call.
+			continue
+		}
+		if err := r.client.SettleReservation(state.Space.ValueString(), block, child.Id.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Delete AzureIpam Reservation Group",
+				fmt.Sprintf("reservations[%d] id=%s error=%s", i, child.Id.ValueString(), err.Error()),
+			)
+			return
+		}
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *reservationGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ipamclient.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *azureipam.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// childReservation pairs a created/read reservation with the block it lives in.
+type childReservation struct {
+	reservation *ipamclient.Reservation
+	block       string
+}
+
+// createInFirstAvailableBlock tries blocks in order and returns the reservation
+// created in the first block that has room for the request.
+func (r *reservationGroupResource) createInFirstAvailableBlock(space string, blocks []string, request ipamclient.CreateReservationRequest) (*ipamclient.Reservation, string, error) {
+	var lastErr error
+	for _, block := range blocks {
+		reservation, err := r.client.CreateReservation(space, block, request)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return reservation, block, nil
+	}
+	return nil, "", fmt.Errorf("no candidate block in %v had room for a /%d reservation, last error: %w", blocks, request.Size, lastErr)
+}
+
+// findInBlocks looks up a reservation id across the fallback block list.
+func (r *reservationGroupResource) findInBlocks(space string, blocks []string, id string) (*ipamclient.Reservation, string, error) {
+	var lastErr error
+	for _, block := range blocks {
+		reservation, err := r.client.GetReservation(space, block, id)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return reservation, block, nil
+	}
+	return nil, "", fmt.Errorf("reservation %s not found in any of %v, last error: %w", id, blocks, lastErr)
+}
+
+// persistPartialUpdate writes the children processed so far into resp.State
+// when Update fails partway through the request list, so that reservations
+// already created, settled, or updated this call are not left orphaned out
+// of Terraform state. untouched is the tail of the prior state's reservations
+// (requests[i+1:]) that Update never reached this call; it's appended as-is
+// so those still-live reservations aren't dropped out of state either.
+func (r *reservationGroupResource) persistPartialUpdate(ctx context.Context, resp *resource.UpdateResponse, plan *reservationGroupResourceModel, fallbackId types.String, children []childReservation, untouched []reservationGroupChildModel) {
+	if err := setReservationGroupChildren(ctx, plan, children); err != nil {
+		resp.Diagnostics.AddError("Unable to Map Reservation Group Children", err.Error())
+		return
+	}
+	plan.Reservations = append(plan.Reservations, untouched...)
+	if len(plan.Reservations) > 0 {
+		plan.Id = types.StringValue(plan.Reservations[0].Id.ValueString())
+	} else {
+		plan.Id = fallbackId
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// rollback settles every reservation created so far, best-effort, and reports
+// any reservation it failed to settle.
+func (r *reservationGroupResource) rollback(space string, children []childReservation) []error {
+	var errs []error
+	for _, child := range children {
+		if err := r.client.SettleReservation(space, child.block, child.reservation.Id); err != nil {
+			errs = append(errs, fmt.Errorf("reservation %s: %w", child.reservation.Id, err))
+		}
+	}
+	return errs
+}
+
+// rollbackAndWarn rolls back children and, if any could not be settled,
+// appends a warning so the failures aren't silently discarded; those
+// reservations remain allocated in IPAM and must be cleaned up manually.
+func (r *reservationGroupResource) rollbackAndWarn(diags *diag.Diagnostics, space string, children []childReservation) {
+	errs := r.rollback(space, children)
+	if len(errs) == 0 {
+		return
+	}
+	diags.AddWarning(
+		"Reservation Group Rollback Incomplete",
+		fmt.Sprintf("%d of %d previously created reservation(s) could not be settled during rollback and must be cleaned up manually: %v", len(errs), len(children), errs),
+	)
+}
+
+// setReservationGroupChildren maps created/read reservations into the resource's
+// computed reservations attribute.
+func setReservationGroupChildren(ctx context.Context, model *reservationGroupResourceModel, children []childReservation) error {
+	reservations := make([]reservationGroupChildModel, 0, len(children))
+	for _, child := range children {
+		tags, diags := types.MapValueFrom(ctx, types.StringType, child.reservation.Tags)
+		if diags.HasError() {
+			return fmt.Errorf("map tags for reservation %s", child.reservation.Id)
+		}
+		reservations = append(reservations, reservationGroupChildModel{
+			Id:   types.StringValue(child.reservation.Id),
+			Cidr: types.StringValue(child.reservation.Cidr),
+			Tags: tags,
+		})
+	}
+	model.Reservations = reservations
+	return nil
+}
@@ -0,0 +1,501 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	ipamclient "terraform-provider-azureipam/ipamclient"
+
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &reservationResource{}
+	_ resource.ResourceWithConfigure        = &reservationResource{}
+	_ resource.ResourceWithConfigValidators = &reservationResource{}
+)
+
+// NewReservationResource is a helper function to simplify the provider implementation.
+func NewReservationResource() resource.Resource {
+	return &reservationResource{}
+}
+
+// reservationResource is the resource implementation.
+type reservationResource struct {
+	client *ipamclient.Client
+}
+
+// reservationResourceModel maps the resource schema data.
+type reservationResourceModel struct {
+	Id                     types.String      `tfsdk:"id"`
+	Space                  types.String      `tfsdk:"space"`
+	Block                  types.String      `tfsdk:"block"`
+	Blocks                 types.List        `tfsdk:"blocks"`
+	BlockSelectionStrategy types.String      `tfsdk:"block_selection_strategy"`
+	SelectedBlock          types.String      `tfsdk:"selected_block"`
+	Size                   types.Int64       `tfsdk:"size"`
+	Cidr                   types.String      `tfsdk:"cidr"`
+	Description            types.String      `tfsdk:"description"`
+	CreatedOn              timetypes.RFC3339 `tfsdk:"created_on"`
+	CreatedBy              types.String      `tfsdk:"created_by"`
+	SettledOn              timetypes.RFC3339 `tfsdk:"settled_on"`
+	SettledBy              types.String      `tfsdk:"settled_by"`
+	Status                 types.String      `tfsdk:"status"`
+	Tags                   types.Map         `tfsdk:"tags"`
+	Network                types.String      `tfsdk:"network"`
+	Netmask                types.String      `tfsdk:"netmask"`
+	PrefixLength           types.Int64       `tfsdk:"prefix_length"`
+	Gateway                types.String      `tfsdk:"gateway"`
+	Broadcast              types.String      `tfsdk:"broadcast"`
+	Family                 types.Int64       `tfsdk:"family"`
+	UsableHostCount        types.String      `tfsdk:"usable_host_count"`
+}
+
+// Metadata returns the resource type name.
+func (r *reservationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_reservation"
+}
+
+// ConfigValidators enforces that `block` and `blocks` are truly mutually
+// exclusive, rather than leaving `block` to silently win if both are set.
+func (r *reservationResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("block"),
+			path.MatchRoot("blocks"),
+		),
+	}
+}
+
+// Schema defines the schema for the resource.
+func (r *reservationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The reservation resource allows you to reserve a CIDR of a given size within a space and block.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier of the reservation.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"space": schema.StringAttribute{
+				Description: "Name of the space where the reservation is allocated.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"block": schema.StringAttribute{
+				Description: "Name of the block where the reservation is allocated (within the specified space). Mutually exclusive with `blocks`; exactly one of the two must be set.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"blocks": schema.ListAttribute{
+				Description: "Ordered candidate blocks to try for this reservation (within the specified space). Mutually exclusive with `block`; exactly one of the two must be set.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"block_selection_strategy": schema.StringAttribute{
+				Description: "How to choose among `blocks`: `first_match` (default, try in order), `most_free` (try the block with the most free address space first), or `least_free` (try the block with the least free address space first). Ignored when `block` is set.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"selected_block": schema.StringAttribute{
+				Description: "The block that ended up holding the reservation. Set to `block` when specified, or to whichever entry in `blocks` succeeded. Reads re-resolve this block only, without re-probing the others.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"size": schema.Int64Attribute{
+				Description: "Prefix length of the CIDR to reserve, e.g. 24 for a /24.",
+				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"cidr": schema.StringAttribute{
+				Description: "The assigned and reserved range, in cidr notation.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "Description text that describe the reservation.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"created_on": schema.StringAttribute{
+				CustomType:  timetypes.RFC3339Type{},
+				Description: "The date and time that the reservation was created.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_by": schema.StringAttribute{
+				Description: "Email or identification of user that created the reservation.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"settled_on": schema.StringAttribute{
+				CustomType:  timetypes.RFC3339Type{},
+				Description: "The date and time when the reservation was settled.",
+				Computed:    true,
+			},
+			"settled_by": schema.StringAttribute{
+				Description: "Email or identification of user that have settled the reservation.",
+				Computed:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of the reservation, a 'wait' status indicates that is waiting for the related vnet creation.",
+				Computed:    true,
+			},
+			"tags": schema.MapAttribute{
+				Description: "Tags to associate with the reservation.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"network": schema.StringAttribute{
+				Description: "The network address of the reserved cidr.",
+				Computed:    true,
+			},
+			"netmask": schema.StringAttribute{
+				Description: "The netmask of the reserved cidr, in dotted-quad notation for IPv4 or as a prefix length for IPv6.",
+				Computed:    true,
+			},
+			"prefix_length": schema.Int64Attribute{
+				Description: "The prefix length of the reserved cidr.",
+				Computed:    true,
+			},
+			"gateway": schema.StringAttribute{
+				Description: "The first (or last, depending on the provider's gateway_position setting) usable host address in the reserved cidr.",
+				Computed:    true,
+			},
+			"broadcast": schema.StringAttribute{
+				Description: "The broadcast address of the reserved cidr. Only set for IPv4 reservations.",
+				Computed:    true,
+			},
+			"family": schema.Int64Attribute{
+				Description: "The IP address family of the reserved cidr, 4 or 6.",
+				Computed:    true,
+			},
+			"usable_host_count": schema.StringAttribute{
+				Description: "The number of usable host addresses in the reserved cidr, as a string to accommodate large IPv6 ranges.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *reservationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan reservationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var tags map[string]string
+	if !plan.Tags.IsNull() {
+		resp.Diagnostics.Append(plan.Tags.ElementsAs(ctx, &tags, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if plan.BlockSelectionStrategy.IsNull() || plan.BlockSelectionStrategy.IsUnknown() {
+		plan.BlockSelectionStrategy = types.StringValue("first_match")
+	}
+
+	candidates, diags := r.resolveCandidateBlocks(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createRequest := ipamclient.CreateReservationRequest{
+		Size:        int(plan.Size.ValueInt64()),
+		Description: plan.Description.ValueString(),
+		Tags:        tags,
+	}
+
+	var reservation *ipamclient.Reservation
+	var selectedBlock string
+	var lastErr error
+	for _, block := range candidates {
+		created, err := r.client.CreateReservation(plan.Space.ValueString(), block, createRequest)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		reservation = created
+		selectedBlock = block
+		break
+	}
+	if reservation == nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create AzureIpam Reservation",
+			fmt.Sprintf("space=%s candidate blocks=%v error=%s", plan.Space.ValueString(), candidates, lastErr),
+		)
+		return
+	}
+
+	if err := flattenReservation(reservation, &plan, r.client.GatewayPosition); err != nil {
+		resp.Diagnostics.AddError("Unable to Derive Network Attributes", err.Error())
+		return
+	}
+	tagsValue, tagDiags := types.MapValueFrom(ctx, types.StringType, reservation.Tags)
+	resp.Diagnostics.Append(tagDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Tags = tagsValue
+	plan.SelectedBlock = types.StringValue(selectedBlock)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// resolveCandidateBlocks returns the ordered list of blocks to try for Create,
+// applying block_selection_strategy when `blocks` (rather than a single
+// `block`) was configured.
+func (r *reservationResource) resolveCandidateBlocks(ctx context.Context, plan reservationResourceModel) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !plan.Block.IsNull() && plan.Block.ValueString() != "" {
+		return []string{plan.Block.ValueString()}, diags
+	}
+
+	if plan.Blocks.IsNull() {
+		diags.AddError(
+			"Invalid Configuration",
+			"Exactly one of 'block' or 'blocks' must be specified.",
+		)
+		return nil, diags
+	}
+
+	var blocks []string
+	diags.Append(plan.Blocks.ElementsAs(ctx, &blocks, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	if len(blocks) == 0 {
+		diags.AddError(
+			"Invalid Configuration",
+			"At least one block must be specified in 'blocks'.",
+		)
+		return nil, diags
+	}
+
+	strategy := plan.BlockSelectionStrategy.ValueString()
+	if strategy == "" {
+		strategy = "first_match"
+	}
+	if strategy == "first_match" {
+		return blocks, diags
+	}
+
+	type candidate struct {
+		block string
+		free  float64
+	}
+	candidates := make([]candidate, 0, len(blocks))
+	for _, block := range blocks {
+		utilization, err := r.client.GetBlockUtilization(plan.Space.ValueString(), block)
+		if err != nil {
+			diags.AddError(
+				"Unable to Get Block Utilization",
+				fmt.Sprintf("space=%s block=%s error=%s", plan.Space.ValueString(), block, err.Error()),
+			)
+			return nil, diags
+		}
+		candidates = append(candidates, candidate{block: block, free: utilization.FreePercentage})
+	}
+
+	switch strategy {
+	case "most_free":
+		sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].free > candidates[j].free })
+	case "least_free":
+		sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].free < candidates[j].free })
+	default:
+		diags.AddError(
+			"Invalid Configuration",
+			"block_selection_strategy must be one of 'first_match', 'most_free', or 'least_free'.",
+		)
+		return nil, diags
+	}
+
+	ordered := make([]string, len(candidates))
+	for i, c := range candidates {
+		ordered[i] = c.block
+	}
+	return ordered, diags
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *reservationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state reservationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reservation, err := r.client.GetReservation(state.Space.ValueString(), state.SelectedBlock.ValueString(), state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read AzureIpam Reservation",
+			fmt.Sprintf("space=%s block=%s id=%s error=%s", state.Space.ValueString(), state.SelectedBlock.ValueString(), state.Id.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	if err := flattenReservation(reservation, &state, r.client.GatewayPosition); err != nil {
+		resp.Diagnostics.AddError("Unable to Derive Network Attributes", err.Error())
+		return
+	}
+	tagsValue, diags := types.MapValueFrom(ctx, types.StringType, reservation.Tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Tags = tagsValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *reservationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan reservationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var tags map[string]string
+	if !plan.Tags.IsNull() {
+		resp.Diagnostics.Append(plan.Tags.ElementsAs(ctx, &tags, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	reservation, err := r.client.UpdateReservation(plan.Space.ValueString(), plan.SelectedBlock.ValueString(), plan.Id.ValueString(), ipamclient.UpdateReservationRequest{
+		Description: plan.Description.ValueString(),
+		Tags:        tags,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update AzureIpam Reservation",
+			fmt.Sprintf("space=%s block=%s id=%s error=%s", plan.Space.ValueString(), plan.SelectedBlock.ValueString(), plan.Id.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	if err := flattenReservation(reservation, &plan, r.client.GatewayPosition); err != nil {
+		resp.Diagnostics.AddError("Unable to Derive Network Attributes", err.Error())
+		return
+	}
+	tagsValue, diags := types.MapValueFrom(ctx, types.StringType, reservation.Tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Tags = tagsValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete settles the reservation, releasing it back to the block.
+func (r *reservationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state reservationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SettleReservation(state.Space.ValueString(), state.SelectedBlock.ValueString(), state.Id.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Delete AzureIpam Reservation",
+			fmt.Sprintf("space=%s block=%s id=%s error=%s", state.Space.ValueString(), state.SelectedBlock.ValueString(), state.Id.ValueString(), err.Error()),
+		)
+		return
+	}
+}
+
+// flattenReservation maps an ipamclient.Reservation onto a reservationResourceModel,
+// deriving the network attributes (network, netmask, gateway, broadcast, family,
+// usable_host_count) from the reservation's cidr. gatewayPosition comes from
+// the configured client so it's scoped to the provider instance that owns it.
+func flattenReservation(reservation *ipamclient.Reservation, model *reservationResourceModel, gatewayPosition string) error {
+	model.Id = types.StringValue(reservation.Id)
+	model.Cidr = types.StringValue(reservation.Cidr)
+	model.Description = types.StringValue(reservation.Description)
+	model.CreatedOn = timetypes.NewRFC3339TimeValue(reservation.CreatedOn)
+	model.CreatedBy = types.StringValue(reservation.CreatedBy)
+	model.SettledOn = timetypes.NewRFC3339TimeValue(reservation.SettledOn)
+	model.SettledBy = types.StringValue(reservation.SettledBy)
+	model.Status = types.StringValue(reservation.Status)
+
+	attrs, err := computeNetworkAttrs(reservation.Cidr, gatewayPosition)
+	if err != nil {
+		return err
+	}
+	model.Network = types.StringValue(attrs.Network)
+	model.Netmask = types.StringValue(attrs.Netmask)
+	model.PrefixLength = types.Int64Value(attrs.PrefixLength)
+	model.Gateway = types.StringValue(attrs.Gateway)
+	model.Family = types.Int64Value(attrs.Family)
+	model.UsableHostCount = types.StringValue(attrs.UsableHostCount)
+	if attrs.Family == 4 {
+		model.Broadcast = types.StringValue(attrs.Broadcast)
+	} else {
+		model.Broadcast = types.StringNull()
+	}
+
+	return nil
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *reservationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ipamclient.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *azureipam.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
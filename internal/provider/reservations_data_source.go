@@ -0,0 +1,328 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"time"
+
+	ipamclient "terraform-provider-azureipam/ipamclient"
+
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &reservationsDataSource{}
+	_ datasource.DataSourceWithConfigure = &reservationsDataSource{}
+)
+
+// NewReservationsDataSource is a helper function to simplify the provider implementation.
+func NewReservationsDataSource() datasource.DataSource {
+	return &reservationsDataSource{}
+}
+
+// reservationsDataSource is the data source implementation.
+type reservationsDataSource struct {
+	client *ipamclient.Client
+}
+
+// reservationsDataSourceModel maps the data source schema data.
+type reservationsDataSourceModel struct {
+	Space         types.String           `tfsdk:"space"`
+	Blocks        types.List             `tfsdk:"blocks"`
+	Status        types.String           `tfsdk:"status"`
+	CreatedBy     types.String           `tfsdk:"created_by"`
+	Tag           types.Map              `tfsdk:"tag"`
+	CidrContains  types.String           `tfsdk:"cidr_contains"`
+	CreatedAfter  timetypes.RFC3339      `tfsdk:"created_after"`
+	CreatedBefore timetypes.RFC3339      `tfsdk:"created_before"`
+	Reservations  []reservationItemModel `tfsdk:"reservations"`
+}
+
+// reservationItemModel is one list element: the stored fields of the
+// single-reservation schema plus the block the reservation was found in. The
+// derived network attributes (network, netmask, prefix_length, gateway,
+// broadcast, family, usable_host_count) are not included.
+type reservationItemModel struct {
+	Id          types.String      `tfsdk:"id"`
+	Block       types.String      `tfsdk:"block"`
+	Cidr        types.String      `tfsdk:"cidr"`
+	Description types.String      `tfsdk:"description"`
+	CreatedOn   timetypes.RFC3339 `tfsdk:"created_on"`
+	CreatedBy   types.String      `tfsdk:"created_by"`
+	SettledOn   timetypes.RFC3339 `tfsdk:"settled_on"`
+	SettledBy   types.String      `tfsdk:"settled_by"`
+	Status      types.String      `tfsdk:"status"`
+	Tags        types.Map         `tfsdk:"tags"`
+}
+
+// Metadata returns the data source type name.
+func (d *reservationsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_reservations"
+}
+
+// Schema defines the schema for the data source.
+func (d *reservationsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	reservationAttrTypes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Description: "The unique identifier of the reservation.",
+			Computed:    true,
+		},
+		"block": schema.StringAttribute{
+			Description: "Name of the block the reservation was found in.",
+			Computed:    true,
+		},
+		"cidr": schema.StringAttribute{
+			Description: "The assigned and reserved range, in cidr notation.",
+			Computed:    true,
+		},
+		"description": schema.StringAttribute{
+			Description: "Description text that describe the reservation.",
+			Computed:    true,
+		},
+		"created_on": schema.StringAttribute{
+			CustomType:  timetypes.RFC3339Type{},
+			Description: "The date and time that the reservation was created.",
+			Computed:    true,
+		},
+		"created_by": schema.StringAttribute{
+			Description: "Email or identification of user that created the reservation.",
+			Computed:    true,
+		},
+		"settled_on": schema.StringAttribute{
+			CustomType:  timetypes.RFC3339Type{},
+			Description: "The date and time when the reservation was settled.",
+			Computed:    true,
+		},
+		"settled_by": schema.StringAttribute{
+			Description: "Email or identification of user that have settled the reservation.",
+			Computed:    true,
+		},
+		"status": schema.StringAttribute{
+			Description: "Status of the reservation, a 'wait' status indicates that is waiting for the related vnet creation.",
+			Computed:    true,
+		},
+		"tags": schema.MapAttribute{
+			Description: "Auto-generated tags for the reservation.",
+			Computed:    true,
+			ElementType: types.StringType,
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "The reservations data source allows you to retrieve every reservation in the specified space and blocks, optionally narrowed by filters.",
+		Attributes: map[string]schema.Attribute{
+			"space": schema.StringAttribute{
+				Description: "Name of the space to list reservations from.",
+				Required:    true,
+			},
+			"blocks": schema.ListAttribute{
+				Description: "List of block names to list reservations from (within the specified space). Every block is queried and the results are concatenated.",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+			"status": schema.StringAttribute{
+				Description: "Only return reservations whose status matches exactly (e.g. 'wait', 'active', 'settled').",
+				Optional:    true,
+			},
+			"created_by": schema.StringAttribute{
+				Description: "Only return reservations created by this email or identification.",
+				Optional:    true,
+			},
+			"tag": schema.MapAttribute{
+				Description: "Only return reservations whose tags contain every key/value pair given here.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"cidr_contains": schema.StringAttribute{
+				Description: "Only return reservations whose cidr falls within this cidr.",
+				Optional:    true,
+			},
+			"created_after": schema.StringAttribute{
+				CustomType:  timetypes.RFC3339Type{},
+				Description: "Only return reservations created on or after this RFC3339 timestamp.",
+				Optional:    true,
+			},
+			"created_before": schema.StringAttribute{
+				CustomType:  timetypes.RFC3339Type{},
+				Description: "Only return reservations created on or before this RFC3339 timestamp.",
+				Optional:    true,
+			},
+			"reservations": schema.ListNestedAttribute{
+				Description: "The reservations matching the given space, blocks and filters.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: reservationAttrTypes,
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *reservationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state reservationsDataSourceModel
+
+	// Read Terraform configuration state into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var blocks []string
+	if diags := state.Blocks.ElementsAs(ctx, &blocks, false); diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	if len(blocks) == 0 {
+		resp.Diagnostics.AddError(
+			"Invalid Configuration",
+			"At least one block must be specified in 'blocks'.",
+		)
+		return
+	}
+
+	var tagFilter map[string]string
+	if !state.Tag.IsNull() {
+		if diags := state.Tag.ElementsAs(ctx, &tagFilter, false); diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+	}
+
+	var containsPrefix netip.Prefix
+	if !state.CidrContains.IsNull() && state.CidrContains.ValueString() != "" {
+		parsed, err := netip.ParsePrefix(state.CidrContains.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Configuration",
+				fmt.Sprintf("'cidr_contains' is not a valid cidr: %s", err.Error()),
+			)
+			return
+		}
+		containsPrefix = parsed.Masked()
+	}
+
+	var createdAfter, createdBefore time.Time
+	if !state.CreatedAfter.IsNull() {
+		t, diags := state.CreatedAfter.ValueRFC3339Time()
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		createdAfter = t
+	}
+	if !state.CreatedBefore.IsNull() {
+		t, diags := state.CreatedBefore.ValueRFC3339Time()
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		createdBefore = t
+	}
+
+	var items []reservationItemModel
+	for _, block := range blocks {
+		reservations, err := d.client.ListReservations(state.Space.ValueString(), block)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to List AzureIpam Reservations",
+				fmt.Sprintf("space=%s block=%s error=%s", state.Space.ValueString(), block, err.Error()),
+			)
+			return
+		}
+
+		for _, r := range reservations {
+			if !matchesFilters(r, state, tagFilter, containsPrefix, createdAfter, createdBefore) {
+				continue
+			}
+
+			item := reservationItemModel{
+				Id:          types.StringValue(r.Id),
+				Block:       types.StringValue(block),
+				Cidr:        types.StringValue(r.Cidr),
+				Description: types.StringValue(r.Description),
+				CreatedOn:   timetypes.NewRFC3339TimeValue(r.CreatedOn),
+				CreatedBy:   types.StringValue(r.CreatedBy),
+				SettledOn:   timetypes.NewRFC3339TimeValue(r.SettledOn),
+				SettledBy:   types.StringValue(r.SettledBy),
+				Status:      types.StringValue(r.Status),
+			}
+			tags, diags := types.MapValueFrom(ctx, types.StringType, r.Tags)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			item.Tags = tags
+			items = append(items, item)
+		}
+	}
+
+	state.Reservations = items
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// matchesFilters reports whether a reservation satisfies every filter set on the data source.
+func matchesFilters(r *ipamclient.Reservation, state reservationsDataSourceModel, tagFilter map[string]string, containsPrefix netip.Prefix, createdAfter, createdBefore time.Time) bool {
+	if !state.Status.IsNull() && state.Status.ValueString() != "" && r.Status != state.Status.ValueString() {
+		return false
+	}
+	if !state.CreatedBy.IsNull() && state.CreatedBy.ValueString() != "" && r.CreatedBy != state.CreatedBy.ValueString() {
+		return false
+	}
+	for k, v := range tagFilter {
+		if r.Tags[k] != v {
+			return false
+		}
+	}
+	if containsPrefix.IsValid() {
+		reservationPrefix, err := netip.ParsePrefix(r.Cidr)
+		if err != nil {
+			return false
+		}
+		reservationPrefix = reservationPrefix.Masked()
+		// r.Cidr is a subset of containsPrefix only if it's at least as
+		// specific (a longer or equal prefix) and its network address falls
+		// within containsPrefix; a shorter prefix is never a subset
+		// even if its network address happens to land inside the range.
+		if reservationPrefix.Bits() < containsPrefix.Bits() || !containsPrefix.Contains(reservationPrefix.Addr()) {
+			return false
+		}
+	}
+	if !createdAfter.IsZero() && r.CreatedOn.Before(createdAfter) {
+		return false
+	}
+	if !createdBefore.IsZero() && r.CreatedOn.After(createdBefore) {
+		return false
+	}
+	return true
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *reservationsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ipamclient.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *azureipam.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,194 @@
+// Package ipamclient is a thin HTTP client for the Azure IPAM REST API.
+package ipamclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Client is a configured handle to an Azure IPAM instance.
+type Client struct {
+	Endpoint   string
+	Token      string
+	TenantID   string
+	HTTPClient *http.Client
+
+	// GatewayPosition is "first_host" (default) or "last_host", and controls
+	// which usable host in a reservation's cidr is reported as its gateway.
+	// It is carried on the client (rather than a package-level variable) so
+	// that multiple provider instances configured differently in the same
+	// process don't stomp on each other's setting.
+	GatewayPosition string
+}
+
+// NewClient returns a Client ready to talk to the given IPAM endpoint.
+func NewClient(endpoint, token string) *Client {
+	return &Client{
+		Endpoint: endpoint,
+		Token:    token,
+		HTTPClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		GatewayPosition: "first_host",
+	}
+}
+
+// Config is the resolved set of connection settings a Client is built from,
+// regardless of whether they came from the provider block, a config file
+// profile, or the environment.
+type Config struct {
+	Endpoint        string
+	Token           string
+	TenantID        string
+	GatewayPosition string
+}
+
+// NewClientFromConfig returns a Client built from a resolved Config.
+func NewClientFromConfig(cfg Config) *Client {
+	client := NewClient(cfg.Endpoint, cfg.Token)
+	client.TenantID = cfg.TenantID
+	if cfg.GatewayPosition != "" {
+		client.GatewayPosition = cfg.GatewayPosition
+	}
+	return client
+}
+
+// Reservation represents a single CIDR reservation tracked by IPAM.
+type Reservation struct {
+	Id          string            `json:"id"`
+	Cidr        string            `json:"cidr"`
+	Description string            `json:"desc"`
+	CreatedOn   time.Time         `json:"createdOn"`
+	CreatedBy   string            `json:"createdBy"`
+	SettledOn   time.Time         `json:"settledOn"`
+	SettledBy   string            `json:"settledBy"`
+	Status      string            `json:"status"`
+	Tags        map[string]string `json:"tags"`
+}
+
+func (c *Client) do(method, path string, body interface{}, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshal request body: %w", err)
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, c.Endpoint+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Content-Type", "application/json")
+	if c.TenantID != "" {
+		req.Header.Set("X-IPAM-Tenant-Id", c.TenantID)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response body: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("Reservation not found: space/block/id combination did not match any reservation")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("unmarshal response body: %w", err)
+	}
+	return nil
+}
+
+// GetReservation looks up a single reservation by id within a space/block.
+func (c *Client) GetReservation(space, block, id string) (*Reservation, error) {
+	var reservation Reservation
+	path := fmt.Sprintf("/api/spaces/%s/blocks/%s/reservations/%s", space, block, id)
+	if err := c.do(http.MethodGet, path, nil, &reservation); err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// ListReservations returns every reservation currently tracked in a space/block.
+func (c *Client) ListReservations(space, block string) ([]*Reservation, error) {
+	var reservations []*Reservation
+	path := fmt.Sprintf("/api/spaces/%s/blocks/%s/reservations", space, block)
+	if err := c.do(http.MethodGet, path, nil, &reservations); err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+// CreateReservationRequest is the payload accepted when reserving a new CIDR.
+type CreateReservationRequest struct {
+	Size        int               `json:"size"`
+	Description string            `json:"desc"`
+	Tags        map[string]string `json:"tags,omitempty"`
+}
+
+// CreateReservation reserves a new CIDR of the requested size within a space/block.
+func (c *Client) CreateReservation(space, block string, request CreateReservationRequest) (*Reservation, error) {
+	var reservation Reservation
+	path := fmt.Sprintf("/api/spaces/%s/blocks/%s/reservations", space, block)
+	if err := c.do(http.MethodPost, path, request, &reservation); err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// UpdateReservationRequest is the payload accepted when updating a reservation's metadata.
+type UpdateReservationRequest struct {
+	Description string            `json:"desc"`
+	Tags        map[string]string `json:"tags,omitempty"`
+}
+
+// UpdateReservation updates the description and tags of an existing reservation.
+func (c *Client) UpdateReservation(space, block, id string, request UpdateReservationRequest) (*Reservation, error) {
+	var reservation Reservation
+	path := fmt.Sprintf("/api/spaces/%s/blocks/%s/reservations/%s", space, block, id)
+	if err := c.do(http.MethodPatch, path, request, &reservation); err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// SettleReservation marks a reservation as settled, releasing it back to the block.
+func (c *Client) SettleReservation(space, block, id string) error {
+	path := fmt.Sprintf("/api/spaces/%s/blocks/%s/reservations/%s", space, block, id)
+	return c.do(http.MethodDelete, path, nil, nil)
+}
+
+// BlockUtilization describes how much free address space remains in a block.
+type BlockUtilization struct {
+	Block          string  `json:"name"`
+	FreePercentage float64 `json:"free_percent"`
+}
+
+// GetBlockUtilization returns the current free-space utilization of a block.
+func (c *Client) GetBlockUtilization(space, block string) (*BlockUtilization, error) {
+	var utilization BlockUtilization
+	path := fmt.Sprintf("/api/spaces/%s/blocks/%s/utilization", space, block)
+	if err := c.do(http.MethodGet, path, nil, &utilization); err != nil {
+		return nil, err
+	}
+	utilization.Block = block
+	return &utilization, nil
+}